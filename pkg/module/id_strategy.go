@@ -0,0 +1,107 @@
+package module
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// k8sMaxNameLength is the maximum length Kubernetes allows for an object name.
+const k8sMaxNameLength = 253
+
+// IDStrategy computes the Kusion resource ID for a Kubernetes object from its type and object
+// metadata. Module authors can opt into a non-default strategy via WithIDStrategy when the
+// default apiVersion:Kind:ns:name format does not fit their use case.
+type IDStrategy interface {
+	// ID returns the Kusion resource ID for the given type and object metadata.
+	ID(typeMeta metav1.TypeMeta, objectMeta metav1.ObjectMeta) (string, error)
+}
+
+// defaultIDStrategy reproduces the historical apiVersion:Kind[:ns]:name format of
+// KubernetesResourceID, and is used when no other IDStrategy is configured.
+type defaultIDStrategy struct{}
+
+func (defaultIDStrategy) ID(typeMeta metav1.TypeMeta, objectMeta metav1.ObjectMeta) (string, error) {
+	return KubernetesResourceID(typeMeta, objectMeta), nil
+}
+
+// gvrIDStrategy produces "group/version/resource/ns/name" IDs, resolving the resource (plural)
+// name for a GroupVersionKind through the supplied resolve function, e.g. backed by a
+// RESTMapper doing cluster-scoped API discovery.
+type gvrIDStrategy struct {
+	resolve func(typeMeta metav1.TypeMeta) (resource string, err error)
+}
+
+// NewGVRIDStrategy returns an IDStrategy that formats IDs as "group/version/resource/ns/name",
+// resolving the plural resource name for a type via resolve.
+func NewGVRIDStrategy(resolve func(typeMeta metav1.TypeMeta) (resource string, err error)) IDStrategy {
+	return &gvrIDStrategy{resolve: resolve}
+}
+
+func (s *gvrIDStrategy) ID(typeMeta metav1.TypeMeta, objectMeta metav1.ObjectMeta) (string, error) {
+	resource, err := s.resolve(typeMeta)
+	if err != nil {
+		return "", fmt.Errorf("resolve GVR for %s/%s: %w", typeMeta.APIVersion, typeMeta.Kind, err)
+	}
+
+	group, version := splitAPIVersion(typeMeta.APIVersion)
+	parts := []string{group, version, resource}
+	if objectMeta.Namespace != "" {
+		parts = append(parts, objectMeta.Namespace)
+	}
+	parts = append(parts, objectMeta.Name)
+	return strings.Join(parts, "/"), nil
+}
+
+func splitAPIVersion(apiVersion string) (group, version string) {
+	parts := strings.SplitN(apiVersion, "/", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return "", parts[0]
+}
+
+// nameTruncator is implemented by IDStrategy implementations that also need to shorten the
+// underlying Kubernetes object's metadata.name before it is applied, not just the bookkeeping
+// Kusion resource ID string. WrapK8sResourceToKusionResource checks for it and, when present,
+// rewrites the object's name in place ahead of computing the ID and converting to Attributes.
+type nameTruncator interface {
+	// TruncateName returns a (possibly shortened) name to use in place of name, and whether it
+	// was actually changed.
+	TruncateName(name string) (truncated string, changed bool)
+}
+
+// hashedIDStrategy falls back to the default apiVersion:Kind[:ns]:name format, but hashes the
+// object's name whenever combining it with appName would exceed Kubernetes' 253-character limit
+// on object names. Because it also implements nameTruncator, WrapK8sResourceToKusionResource
+// applies the same shortened name to the Kubernetes object itself, not just to the Kusion
+// resource ID, so the object that gets applied stays within the apiserver's name limit.
+type hashedIDStrategy struct {
+	appName string
+}
+
+// NewHashedIDStrategy returns an IDStrategy for resources whose names, once combined with
+// appName (typically produced by UniqueAppName), may exceed Kubernetes' 253-character name
+// limit.
+func NewHashedIDStrategy(appName string) IDStrategy {
+	return &hashedIDStrategy{appName: appName}
+}
+
+func (s *hashedIDStrategy) ID(typeMeta metav1.TypeMeta, objectMeta metav1.ObjectMeta) (string, error) {
+	objectMeta.Name, _ = s.TruncateName(objectMeta.Name)
+	return KubernetesResourceID(typeMeta, objectMeta), nil
+}
+
+// TruncateName implements nameTruncator, replacing name with a short SHA-256-derived hash
+// whenever len(s.appName)+len(name) would exceed k8sMaxNameLength.
+func (s *hashedIDStrategy) TruncateName(name string) (string, bool) {
+	if len(s.appName)+len(name) <= k8sMaxNameLength {
+		return name, false
+	}
+
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])[:16], true
+}
@@ -0,0 +1,120 @@
+package module
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGVRIDStrategyID(t *testing.T) {
+	cases := []struct {
+		name       string
+		typeMeta   metav1.TypeMeta
+		objectMeta metav1.ObjectMeta
+		resolve    func(metav1.TypeMeta) (string, error)
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "namespaced resource",
+			typeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			objectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"},
+			resolve:    func(metav1.TypeMeta) (string, error) { return "deployments", nil },
+			want:       "apps/v1/deployments/default/nginx",
+		},
+		{
+			name:       "cluster-scoped resource has no namespace segment",
+			typeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			objectMeta: metav1.ObjectMeta{Name: "prod"},
+			resolve:    func(metav1.TypeMeta) (string, error) { return "namespaces", nil },
+			want:       "/v1/namespaces/prod",
+		},
+		{
+			name:       "resolve error is wrapped",
+			typeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			objectMeta: metav1.ObjectMeta{Name: "nginx"},
+			resolve:    func(metav1.TypeMeta) (string, error) { return "", fmt.Errorf("no mapping") },
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			strategy := NewGVRIDStrategy(tc.resolve)
+			got, err := strategy.ID(tc.typeMeta, tc.objectMeta)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("ID: expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ID: unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("ID = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHashedIDStrategyID(t *testing.T) {
+	strategy := NewHashedIDStrategy("short-app")
+
+	typeMeta := metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}
+	objectMeta := metav1.ObjectMeta{Namespace: "default", Name: "cm"}
+
+	got, err := strategy.ID(typeMeta, objectMeta)
+	if err != nil {
+		t.Fatalf("ID: unexpected error: %v", err)
+	}
+	want := KubernetesResourceID(typeMeta, objectMeta)
+	if got != want {
+		t.Errorf("ID = %q, want %q (name fits, should be left untouched)", got, want)
+	}
+}
+
+func TestHashedIDStrategyIDTruncatesLongName(t *testing.T) {
+	strategy := NewHashedIDStrategy(strings.Repeat("a", 200))
+
+	typeMeta := metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}
+	longName := strings.Repeat("b", 100)
+	objectMeta := metav1.ObjectMeta{Namespace: "default", Name: longName}
+
+	got, err := strategy.ID(typeMeta, objectMeta)
+	if err != nil {
+		t.Fatalf("ID: unexpected error: %v", err)
+	}
+	if strings.Contains(got, longName) {
+		t.Errorf("ID = %q, still contains the untruncated name %q", got, longName)
+	}
+}
+
+func TestHashedIDStrategyTruncateName(t *testing.T) {
+	strategy := NewHashedIDStrategy(strings.Repeat("a", 200)).(*hashedIDStrategy)
+
+	shortName := "nginx"
+	got, changed := strategy.TruncateName(shortName)
+	if changed {
+		t.Errorf("TruncateName(%q) reported changed, want unchanged", shortName)
+	}
+	if got != shortName {
+		t.Errorf("TruncateName(%q) = %q, want unchanged", shortName, got)
+	}
+
+	longName := strings.Repeat("b", 100)
+	truncated, changed := strategy.TruncateName(longName)
+	if !changed {
+		t.Errorf("TruncateName(%q) reported unchanged, want changed", longName)
+	}
+	if len(truncated) != 16 {
+		t.Errorf("TruncateName(%q) = %q, want a 16-character hash", longName, truncated)
+	}
+
+	again, _ := strategy.TruncateName(longName)
+	if again != truncated {
+		t.Errorf("TruncateName is not deterministic: %q != %q", again, truncated)
+	}
+}
@@ -0,0 +1,119 @@
+package module
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	v1 "kusionstack.io/kusion/pkg/apis/api.kusion.io/v1"
+)
+
+// TerraformConfig is the set of named provider configs declared by a workspace, keyed by
+// provider name (e.g. "aws", "alicloud").
+type TerraformConfig map[string]*ProviderConfig
+
+// providerSourceRE matches a provider source of the form "[host/]namespace/name".
+var providerSourceRE = regexp.MustCompile(`^([a-zA-Z0-9][a-zA-Z0-9.-]*/)?[a-zA-Z0-9_-]+/[a-zA-Z0-9_-]+$`)
+
+// providerMetaSchema describes the ProviderMeta keys a provider requires and recognizes, so
+// ProviderRegistry.Validate can catch typos and missing required fields per-provider.
+type providerMetaSchema struct {
+	required []string
+	known    map[string]struct{}
+}
+
+// knownProviderSchemas holds the ProviderMeta schema for providers this framework has built-in
+// knowledge of. Providers not listed here are only checked for Source and Version.
+var knownProviderSchemas = map[string]providerMetaSchema{
+	"aws":      {required: []string{"region"}, known: map[string]struct{}{"region": {}, "profile": {}, "assumeRole": {}}},
+	"alicloud": {required: []string{"region"}, known: map[string]struct{}{"region": {}, "profile": {}}},
+}
+
+// ProviderRegistry holds the set of named ProviderConfigs available to a module, so module
+// authors can call registry.Resource(...) instead of threading a ProviderConfig through every
+// call site.
+type ProviderRegistry struct {
+	providers map[string]*ProviderConfig
+}
+
+// NewProviderRegistry returns an empty ProviderRegistry.
+func NewProviderRegistry() *ProviderRegistry {
+	return &ProviderRegistry{providers: make(map[string]*ProviderConfig)}
+}
+
+// Register adds or overwrites the ProviderConfig for the given provider name.
+func (r *ProviderRegistry) Register(name string, cfg *ProviderConfig) {
+	r.providers[name] = cfg
+}
+
+// Get returns the ProviderConfig registered under name, and false if none was registered.
+func (r *ProviderRegistry) Get(name string) (*ProviderConfig, bool) {
+	cfg, ok := r.providers[name]
+	return cfg, ok
+}
+
+// Merge overlays the workspace-supplied TerraformConfig onto the registry, registering any
+// provider that has not already been registered. Providers already present in the registry,
+// e.g. ones the module itself requires a specific version of, are left untouched.
+func (r *ProviderRegistry) Merge(workspace TerraformConfig) {
+	for name, cfg := range workspace {
+		if _, ok := r.providers[name]; !ok {
+			r.providers[name] = cfg
+		}
+	}
+}
+
+// Validate checks every registered ProviderConfig for a well-formed semver Version, a Source
+// matching "[host/]namespace/name", and a ProviderMeta consistent with the provider's known
+// schema (if any). A missing required ProviderMeta key, an invalid Version, or an invalid Source
+// is a hard error; an unrecognized ProviderMeta key is only reported as a warning, since
+// knownProviderSchemas is necessarily incomplete and a typo there shouldn't block an otherwise
+// valid provider.
+func (r *ProviderRegistry) Validate() (warnings []string, err error) {
+	var errs []string
+	for name, cfg := range r.providers {
+		if !semver.IsValid("v" + strings.TrimPrefix(cfg.Version, "v")) {
+			errs = append(errs, fmt.Sprintf("provider %q: invalid semver version %q", name, cfg.Version))
+		}
+		if !providerSourceRE.MatchString(cfg.Source) {
+			errs = append(errs, fmt.Sprintf("provider %q: invalid source %q, want [host/]namespace/name", name, cfg.Source))
+		}
+
+		schema, ok := knownProviderSchemas[name]
+		if !ok {
+			continue
+		}
+		for _, key := range schema.required {
+			if _, ok := cfg.ProviderMeta[key]; !ok {
+				errs = append(errs, fmt.Sprintf("provider %q: missing required providerMeta key %q", name, key))
+			}
+		}
+		for key := range cfg.ProviderMeta {
+			if _, ok := schema.known[key]; !ok {
+				warnings = append(warnings, fmt.Sprintf("provider %q: unknown providerMeta key %q", name, key))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return warnings, fmt.Errorf("invalid provider registry:\n%s", strings.Join(errs, "\n"))
+	}
+	return warnings, nil
+}
+
+// Resource builds a Kusion resource for resType/resName under the named provider, resolving
+// its ProviderConfig from the registry instead of requiring the caller to thread one through.
+func (r *ProviderRegistry) Resource(providerName, resType, resName string, attrs map[string]interface{}, dependsOn []string) (*v1.Resource, error) {
+	cfg, ok := r.providers[providerName]
+	if !ok {
+		return nil, fmt.Errorf("provider %q is not registered", providerName)
+	}
+
+	id, err := TerraformResourceID(*cfg, resType, resName)
+	if err != nil {
+		return nil, err
+	}
+	return WrapTFResourceToKusionResource(*cfg, resType, id, attrs, dependsOn)
+}
@@ -0,0 +1,91 @@
+package module
+
+import "testing"
+
+func TestProviderRegistryValidate(t *testing.T) {
+	cases := []struct {
+		name        string
+		cfg         *ProviderConfig
+		wantErr     bool
+		wantWarning bool
+	}{
+		{
+			name:    "valid aws provider",
+			cfg:     &ProviderConfig{Source: "hashicorp/aws", Version: "v5.1.0", ProviderMeta: map[string]any{"region": "us-west-2"}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid semver",
+			cfg:     &ProviderConfig{Source: "hashicorp/aws", Version: "not-a-version", ProviderMeta: map[string]any{"region": "us-west-2"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid source",
+			cfg:     &ProviderConfig{Source: "not_a_valid_source!!", Version: "v5.1.0", ProviderMeta: map[string]any{"region": "us-west-2"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing required providerMeta key",
+			cfg:     &ProviderConfig{Source: "hashicorp/aws", Version: "v5.1.0"},
+			wantErr: true,
+		},
+		{
+			name:        "unknown providerMeta key warns but does not error",
+			cfg:         &ProviderConfig{Source: "hashicorp/aws", Version: "v5.1.0", ProviderMeta: map[string]any{"region": "us-west-2", "bogus": "x"}},
+			wantErr:     false,
+			wantWarning: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := NewProviderRegistry()
+			registry.Register("aws", tc.cfg)
+
+			warnings, err := registry.Validate()
+			if tc.wantErr && err == nil {
+				t.Fatal("Validate: expected an error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("Validate: unexpected error: %v", err)
+			}
+			if tc.wantWarning && len(warnings) == 0 {
+				t.Error("Validate: expected a warning for the unknown providerMeta key, got none")
+			}
+			if !tc.wantWarning && len(warnings) != 0 {
+				t.Errorf("Validate: unexpected warnings: %v", warnings)
+			}
+		})
+	}
+}
+
+func TestProviderRegistryMergeDoesNotOverwriteRegistered(t *testing.T) {
+	registry := NewProviderRegistry()
+	registry.Register("aws", &ProviderConfig{Source: "hashicorp/aws", Version: "v5.1.0"})
+
+	registry.Merge(TerraformConfig{
+		"aws":      {Source: "hashicorp/aws", Version: "v4.0.0"},
+		"alicloud": {Source: "aliyun/alicloud", Version: "v1.2.0"},
+	})
+
+	aws, _ := registry.Get("aws")
+	if aws.Version != "v5.1.0" {
+		t.Errorf("Merge overwrote an already-registered provider: Version = %q, want %q", aws.Version, "v5.1.0")
+	}
+
+	alicloud, ok := registry.Get("alicloud")
+	if !ok {
+		t.Fatal("Merge did not add the workspace-supplied alicloud provider")
+	}
+	if alicloud.Version != "v1.2.0" {
+		t.Errorf("alicloud.Version = %q, want %q", alicloud.Version, "v1.2.0")
+	}
+}
+
+func TestProviderRegistryResourceUnregisteredProvider(t *testing.T) {
+	registry := NewProviderRegistry()
+
+	if _, err := registry.Resource("aws", "aws_vpc", "main", nil, nil); err == nil {
+		t.Fatal("Resource: expected an error for an unregistered provider, got nil")
+	}
+}
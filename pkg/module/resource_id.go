@@ -0,0 +1,78 @@
+package module
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UniqueResourceID returns a short, deterministic ID derived from a SHA-256 hash of the
+// canonicalized spec, prefixed with prefix. It is analogous to Terraform SDK's
+// resource.UniqueId(), but deterministic rather than time-based: the same prefix and spec
+// always produce the same ID, so it is safe to call repeatedly across re-runs of the same
+// module.
+func UniqueResourceID(prefix string, spec any) string {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		data = []byte(fmt.Sprintf("%v", spec))
+	}
+
+	sum := sha256.Sum256(data)
+	encoded := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(sum[:])
+	return prefix + strings.ToLower(encoded[:16])
+}
+
+// ResourceIDRegistry tracks every resource ID produced within a single Spec and reports an
+// error as soon as a collision occurs, instead of letting two resources silently share an ID
+// until the Kusion runtime fails on it later.
+type ResourceIDRegistry struct {
+	mu  sync.Mutex
+	ids map[string]struct{}
+}
+
+// NewResourceIDRegistry returns an empty ResourceIDRegistry.
+func NewResourceIDRegistry() *ResourceIDRegistry {
+	return &ResourceIDRegistry{ids: make(map[string]struct{})}
+}
+
+// Register records id as used, returning an error if it was already registered.
+func (r *ResourceIDRegistry) Register(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.ids[id]; ok {
+		return fmt.Errorf("duplicate resource ID: %s", id)
+	}
+	r.ids[id] = struct{}{}
+	return nil
+}
+
+// RegisterKubernetesResourceID computes the Kusion resource ID for typeMeta/objectMeta via
+// KubernetesResourceID and registers it with r, returning an error if it collides with an ID
+// already produced for this Spec.
+func (r *ResourceIDRegistry) RegisterKubernetesResourceID(typeMeta metav1.TypeMeta, objectMeta metav1.ObjectMeta) (string, error) {
+	id := KubernetesResourceID(typeMeta, objectMeta)
+	if err := r.Register(id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// RegisterTerraformResourceID computes the Kusion resource ID for the Terraform resource via
+// TerraformResourceID and registers it with r, returning an error if it collides with an ID
+// already produced for this Spec.
+func (r *ResourceIDRegistry) RegisterTerraformResourceID(providerCfg ProviderConfig, resType, resName string) (string, error) {
+	id, err := TerraformResourceID(providerCfg, resType, resName)
+	if err != nil {
+		return "", err
+	}
+	if err := r.Register(id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
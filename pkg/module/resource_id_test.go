@@ -0,0 +1,70 @@
+package module
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUniqueResourceIDIsDeterministic(t *testing.T) {
+	spec := map[string]string{"name": "nginx", "namespace": "default"}
+
+	first := UniqueResourceID("pod-", spec)
+	second := UniqueResourceID("pod-", spec)
+	if first != second {
+		t.Errorf("UniqueResourceID is not deterministic: %q != %q", first, second)
+	}
+
+	other := UniqueResourceID("pod-", map[string]string{"name": "nginx", "namespace": "prod"})
+	if first == other {
+		t.Errorf("UniqueResourceID produced the same ID for different specs: %q", first)
+	}
+
+	if got, want := first[:len("pod-")], "pod-"; got != want {
+		t.Errorf("UniqueResourceID prefix = %q, want %q", got, want)
+	}
+}
+
+func TestResourceIDRegistryRegister(t *testing.T) {
+	registry := NewResourceIDRegistry()
+
+	if err := registry.Register("apps/v1:Deployment:default:nginx"); err != nil {
+		t.Fatalf("Register first ID: %v", err)
+	}
+	if err := registry.Register("apps/v1:Deployment:default:nginx"); err == nil {
+		t.Fatal("Register: expected an error for a duplicate ID, got nil")
+	}
+	if err := registry.Register("apps/v1:Deployment:default:other"); err != nil {
+		t.Fatalf("Register distinct ID: %v", err)
+	}
+}
+
+func TestResourceIDRegistryRegisterKubernetesResourceID(t *testing.T) {
+	registry := NewResourceIDRegistry()
+	typeMeta := metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}
+	objectMeta := metav1.ObjectMeta{Namespace: "default", Name: "nginx"}
+
+	id, err := registry.RegisterKubernetesResourceID(typeMeta, objectMeta)
+	if err != nil {
+		t.Fatalf("RegisterKubernetesResourceID: %v", err)
+	}
+	if want := KubernetesResourceID(typeMeta, objectMeta); id != want {
+		t.Errorf("RegisterKubernetesResourceID = %q, want %q", id, want)
+	}
+
+	if _, err := registry.RegisterKubernetesResourceID(typeMeta, objectMeta); err == nil {
+		t.Fatal("RegisterKubernetesResourceID: expected a collision error on re-registering the same resource, got nil")
+	}
+}
+
+func TestResourceIDRegistryRegisterTerraformResourceID(t *testing.T) {
+	registry := NewResourceIDRegistry()
+	providerCfg := ProviderConfig{Source: "hashicorp/aws", Version: "5.1.0"}
+
+	if _, err := registry.RegisterTerraformResourceID(providerCfg, "aws_vpc", "main"); err != nil {
+		t.Fatalf("RegisterTerraformResourceID: %v", err)
+	}
+	if _, err := registry.RegisterTerraformResourceID(providerCfg, "aws_vpc", "main"); err == nil {
+		t.Fatal("RegisterTerraformResourceID: expected a collision error on re-registering the same resource, got nil")
+	}
+}
@@ -0,0 +1,140 @@
+package module
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	v1 "kusionstack.io/kusion/pkg/apis/api.kusion.io/v1"
+)
+
+// ImportTFResource imports an existing cloud resource identified by cloudID into Terraform
+// state via `terraform import`, run against a scratch working directory, then reads the
+// resulting state to build a fully populated Kusion resource. This lets brownfield
+// infrastructure (existing VPCs, RDS instances, etc.) be adopted by a Kusion module without
+// redeclaring every attribute by hand.
+func ImportTFResource(providerCfg ProviderConfig, resType, resName, cloudID string) (*v1.Resource, error) {
+	workDir, err := os.MkdirTemp("", "kusion-tfimport-")
+	if err != nil {
+		return nil, fmt.Errorf("create scratch working directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	if err := writeImportConfig(workDir, providerCfg, resType, resName); err != nil {
+		return nil, err
+	}
+
+	if out, err := runTerraform(workDir, "init", "-input=false"); err != nil {
+		return nil, fmt.Errorf("terraform init: %w: %s", err, out)
+	}
+
+	address := resType + "." + resName
+	if out, err := runTerraform(workDir, "import", "-input=false", address, cloudID); err != nil {
+		return nil, fmt.Errorf("terraform import %s %s: %w: %s", address, cloudID, err, out)
+	}
+
+	attributes, err := readImportedAttributes(workDir, address)
+	if err != nil {
+		return nil, err
+	}
+
+	extensions, err := TerraformProviderExtensions(providerCfg, resType)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := TerraformResourceID(providerCfg, resType, resName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.Resource{
+		ID:         id,
+		Type:       v1.Terraform,
+		Attributes: attributes,
+		Extensions: extensions,
+	}, nil
+}
+
+// writeImportConfig writes a minimal main.tf.json to workDir declaring the provider required
+// for resType and an empty resource block for resType.resName, just enough scaffolding for
+// `terraform init` and `terraform import` to operate against.
+func writeImportConfig(workDir string, providerCfg ProviderConfig, resType, resName string) error {
+	srcAttrs := strings.Split(providerCfg.Source, "/")
+	providerName := srcAttrs[len(srcAttrs)-1]
+
+	config := map[string]interface{}{
+		"terraform": map[string]interface{}{
+			"required_providers": map[string]interface{}{
+				providerName: map[string]interface{}{
+					"source":  providerCfg.Source,
+					"version": providerCfg.Version,
+				},
+			},
+		},
+		"provider": map[string]interface{}{
+			providerName: providerCfg.ProviderMeta,
+		},
+		"resource": map[string]interface{}{
+			resType: map[string]interface{}{
+				resName: map[string]interface{}{},
+			},
+		},
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal scratch terraform config: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(workDir, "main.tf.json"), data, 0o600); err != nil {
+		return fmt.Errorf("write scratch terraform config: %w", err)
+	}
+	return nil
+}
+
+// runTerraform runs the terraform CLI with args in workDir, returning its combined output for
+// inclusion in error messages.
+func runTerraform(workDir string, args ...string) (string, error) {
+	cmd := exec.Command("terraform", args...)
+	cmd.Dir = workDir
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+// readImportedAttributes reads the scratch working directory's terraform.tfstate and returns
+// the attributes of the resource instance at address ("type.name").
+func readImportedAttributes(workDir, address string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(filepath.Join(workDir, "terraform.tfstate"))
+	if err != nil {
+		return nil, fmt.Errorf("read imported terraform state: %w", err)
+	}
+
+	var state struct {
+		Resources []struct {
+			Type      string `json:"type"`
+			Name      string `json:"name"`
+			Instances []struct {
+				Attributes map[string]interface{} `json:"attributes"`
+			} `json:"instances"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse imported terraform state: %w", err)
+	}
+
+	for _, res := range state.Resources {
+		if res.Type+"."+res.Name != address {
+			continue
+		}
+		if len(res.Instances) == 0 {
+			return nil, fmt.Errorf("imported resource %s has no state instances", address)
+		}
+		return res.Instances[0].Attributes, nil
+	}
+
+	return nil, fmt.Errorf("imported resource %s not found in terraform state", address)
+}
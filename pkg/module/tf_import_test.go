@@ -0,0 +1,134 @@
+package module
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteImportConfig(t *testing.T) {
+	workDir := t.TempDir()
+	providerCfg := ProviderConfig{
+		Source:       "hashicorp/aws",
+		Version:      "5.1.0",
+		ProviderMeta: map[string]interface{}{"region": "us-west-2"},
+	}
+
+	if err := writeImportConfig(workDir, providerCfg, "aws_vpc", "main"); err != nil {
+		t.Fatalf("writeImportConfig: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(workDir, "main.tf.json"))
+	if err != nil {
+		t.Fatalf("read main.tf.json: %v", err)
+	}
+
+	var config struct {
+		Terraform struct {
+			RequiredProviders map[string]struct {
+				Source  string `json:"source"`
+				Version string `json:"version"`
+			} `json:"required_providers"`
+		} `json:"terraform"`
+		Provider map[string]map[string]interface{}            `json:"provider"`
+		Resource map[string]map[string]map[string]interface{} `json:"resource"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatalf("unmarshal main.tf.json: %v", err)
+	}
+
+	aws, ok := config.Terraform.RequiredProviders["aws"]
+	if !ok {
+		t.Fatalf("required_providers missing %q", "aws")
+	}
+	if aws.Source != providerCfg.Source || aws.Version != providerCfg.Version {
+		t.Errorf("required_providers[aws] = %+v, want source %q version %q", aws, providerCfg.Source, providerCfg.Version)
+	}
+
+	meta, ok := config.Provider["aws"]
+	if !ok {
+		t.Fatalf("provider block missing %q", "aws")
+	}
+	if meta["region"] != "us-west-2" {
+		t.Errorf("provider[aws][region] = %v, want %q", meta["region"], "us-west-2")
+	}
+
+	if _, ok := config.Resource["aws_vpc"]["main"]; !ok {
+		t.Fatal("resource block missing aws_vpc.main")
+	}
+}
+
+func writeTestState(t *testing.T, workDir string, state interface{}) {
+	t.Helper()
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("marshal test terraform.tfstate: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "terraform.tfstate"), data, 0o600); err != nil {
+		t.Fatalf("write test terraform.tfstate: %v", err)
+	}
+}
+
+func TestReadImportedAttributes(t *testing.T) {
+	workDir := t.TempDir()
+	writeTestState(t, workDir, map[string]interface{}{
+		"resources": []map[string]interface{}{
+			{
+				"type": "aws_vpc",
+				"name": "main",
+				"instances": []map[string]interface{}{
+					{"attributes": map[string]interface{}{"cidr_block": "10.0.0.0/16", "id": "vpc-123"}},
+				},
+			},
+		},
+	})
+
+	attrs, err := readImportedAttributes(workDir, "aws_vpc.main")
+	if err != nil {
+		t.Fatalf("readImportedAttributes: %v", err)
+	}
+	if attrs["id"] != "vpc-123" {
+		t.Errorf("attrs[id] = %v, want %q", attrs["id"], "vpc-123")
+	}
+	if attrs["cidr_block"] != "10.0.0.0/16" {
+		t.Errorf("attrs[cidr_block] = %v, want %q", attrs["cidr_block"], "10.0.0.0/16")
+	}
+}
+
+func TestReadImportedAttributesResourceNotFound(t *testing.T) {
+	workDir := t.TempDir()
+	writeTestState(t, workDir, map[string]interface{}{
+		"resources": []map[string]interface{}{
+			{
+				"type":      "aws_vpc",
+				"name":      "other",
+				"instances": []map[string]interface{}{{"attributes": map[string]interface{}{}}},
+			},
+		},
+	})
+
+	if _, err := readImportedAttributes(workDir, "aws_vpc.main"); err == nil {
+		t.Fatal("readImportedAttributes: expected an error for a missing resource, got nil")
+	}
+}
+
+func TestReadImportedAttributesNoInstances(t *testing.T) {
+	workDir := t.TempDir()
+	writeTestState(t, workDir, map[string]interface{}{
+		"resources": []map[string]interface{}{
+			{"type": "aws_vpc", "name": "main", "instances": []map[string]interface{}{}},
+		},
+	})
+
+	if _, err := readImportedAttributes(workDir, "aws_vpc.main"); err == nil {
+		t.Fatal("readImportedAttributes: expected an error for a resource with no instances, got nil")
+	}
+}
+
+func TestReadImportedAttributesMissingStateFile(t *testing.T) {
+	if _, err := readImportedAttributes(t.TempDir(), "aws_vpc.main"); err == nil {
+		t.Fatal("readImportedAttributes: expected an error for a missing terraform.tfstate, got nil")
+	}
+}
@@ -0,0 +1,516 @@
+// Package tfimport converts existing Terraform configurations into Kusion resources, so
+// module authors can adopt Kusion modules on top of a Terraform stack without hand-rewriting
+// it. It understands both native HCL (*.tf) and its JSON form (*.tf.json), mirroring the
+// mapping approach taken by the Pulumi Kubernetes Terraform converter.
+package tfimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"kusionstack.io/kusion-module-framework/pkg/module"
+	v1 "kusionstack.io/kusion/pkg/apis/api.kusion.io/v1"
+)
+
+// interpRef matches a bare Terraform interpolation reference to another resource's attribute,
+// e.g. "${aws_vpc.main.id}". Only simple type.name.attribute references are supported;
+// anything more elaborate (conditionals, function calls) is left untouched.
+var interpRef = regexp.MustCompile(`^\$\{([a-zA-Z0-9_]+)\.([a-zA-Z0-9_]+)\.([a-zA-Z0-9_.]+)\}$`)
+
+// ResourceBlock is a single parsed Terraform "resource" block.
+type ResourceBlock struct {
+	// Type is the Terraform resource type, e.g. "aws_vpc".
+	Type string
+	// Name is the Terraform resource name, e.g. "main".
+	Name string
+	// Provider is the provider name the resource belongs to, e.g. "aws". Defaults to the
+	// resource type's prefix up to the first underscore when not explicitly set.
+	Provider string
+	// Attributes holds the resource's configuration as parsed, with interpolation references
+	// still in their Terraform "${type.name.attr}" form; Convert translates them into Kusion
+	// "$kusion_path" dependency strings.
+	Attributes map[string]interface{}
+	// DependsOn lists the Terraform addresses (e.g. "aws_vpc.main") from the resource's
+	// explicit "depends_on" attribute. Convert resolves them into Kusion IDs and adds the IDs
+	// of resources referenced through attribute interpolations.
+	DependsOn []string
+}
+
+// Config is a parsed Terraform configuration, ready to be converted into Kusion resources.
+type Config struct {
+	// Providers holds the combined "terraform.required_providers" and "provider" block
+	// configuration, keyed by provider name.
+	Providers map[string]module.ProviderConfig
+	// Resources are the parsed "resource" blocks. Files are appended in directory order; the
+	// order of the blocks within one file is not defined.
+	Resources []ResourceBlock
+}
+
+// ParseDir parses every *.tf and *.tf.json file in dir into a single Config. Files are parsed
+// independently and merged; duplicate provider names are overwritten by later files in
+// directory order.
+func ParseDir(dir string) (*Config, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read terraform directory %s: %w", dir, err)
+	}
+
+	cfg := &Config{Providers: map[string]module.ProviderConfig{}}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		var raw map[string]interface{}
+		switch {
+		case strings.HasSuffix(entry.Name(), ".tf.json"):
+			raw, err = parseJSONFile(path)
+		case strings.HasSuffix(entry.Name(), ".tf"):
+			raw, err = parseHCLFile(path)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+
+		mergeConfig(cfg, raw)
+	}
+
+	return cfg, nil
+}
+
+func parseJSONFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// parseHCLFile parses a native .tf file and re-expresses it as the same generic
+// map[string]interface{} shape produced by parseJSONFile, so both forms can be merged by a
+// single mergeConfig implementation.
+func parseHCLFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := hclparse.NewParser()
+	file, diags := parser.ParseHCL(data, path)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return decodeSyntaxBody(file.Body)
+}
+
+// decodeSyntaxBody walks an hclsyntax.Body's top-level blocks ("terraform", "provider",
+// "resource") into the generic map shape mergeConfig understands.
+func decodeSyntaxBody(body hcl.Body) (map[string]interface{}, error) {
+	content, _, diags := body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "terraform"},
+			{Type: "provider", LabelNames: []string{"name"}},
+			{Type: "resource", LabelNames: []string{"type", "name"}},
+		},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	raw := map[string]interface{}{}
+	for _, block := range content.Blocks {
+		attrs, err := bodyToMap(block.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		switch block.Type {
+		case "terraform":
+			raw["terraform"] = attrs
+		case "provider":
+			providers, _ := raw["provider"].(map[string]interface{})
+			if providers == nil {
+				providers = map[string]interface{}{}
+			}
+			providers[block.Labels[0]] = attrs
+			raw["provider"] = providers
+		case "resource":
+			resources, _ := raw["resource"].(map[string]interface{})
+			if resources == nil {
+				resources = map[string]interface{}{}
+			}
+			byType, _ := resources[block.Labels[0]].(map[string]interface{})
+			if byType == nil {
+				byType = map[string]interface{}{}
+			}
+			byType[block.Labels[1]] = attrs
+			resources[block.Labels[0]] = byType
+			raw["resource"] = resources
+		}
+	}
+
+	return raw, nil
+}
+
+// bodyToMap recursively converts an hclsyntax.Body into the generic map shape mergeConfig and
+// Convert understand: each attribute becomes a JSON-decoded value, and each nested block (e.g.
+// "required_providers", "lifecycle", "ingress") becomes a nested map under its block type, or a
+// list of maps when the same block type repeats. Unlike body.JustAttributes(), this does not
+// error out when a body mixes attributes and nested blocks, which is the common case for both
+// "terraform { required_providers { ... } }" and most non-trivial resource blocks.
+func bodyToMap(body hcl.Body) (map[string]interface{}, error) {
+	syntaxBody, ok := body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("unsupported HCL body type %T", body)
+	}
+
+	out := map[string]interface{}{}
+	for name, attr := range syntaxBody.Attributes {
+		decoded, err := evalExpr(attr.Expr)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", name, err)
+		}
+		out[name] = decoded
+	}
+
+	for _, block := range syntaxBody.Blocks {
+		nested, err := bodyToMap(block.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, ok := out[block.Type]
+		switch {
+		case !ok:
+			out[block.Type] = nested
+		case ok && isList(existing):
+			out[block.Type] = append(existing.([]interface{}), nested)
+		default:
+			out[block.Type] = []interface{}{existing, nested}
+		}
+	}
+
+	return out, nil
+}
+
+func isList(v interface{}) bool {
+	_, ok := v.([]interface{})
+	return ok
+}
+
+// evalExpr converts an hclsyntax expression into a JSON-compatible Go value, without ever
+// calling Value(nil) on an expression that references another resource: a bare reference (used
+// by "depends_on = [aws_vpc.main]") becomes the dotted address string "aws_vpc.main", and a
+// reference wrapped in string interpolation (used by "vpc_id = \"${aws_vpc.main.id}\"") becomes
+// the same "${aws_vpc.main.id}" string interpRef expects. Calling Value(nil) on either would
+// fail with a "Variables not allowed" diagnostic, since there is no resource named aws_vpc.main
+// to actually resolve at parse time; Convert resolves these addresses later, once every
+// resource's Kusion ID is known. Compound expressions (tuples, objects) recurse through evalExpr
+// rather than evaluating as a whole, so a reference nested inside a list or map attribute is
+// preserved the same way. Anything else (literals, operators, function calls) is evaluated
+// normally via Value(nil).
+func evalExpr(expr hclsyntax.Expression) (interface{}, error) {
+	switch e := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		return traversalString(e.Traversal)
+	case *hclsyntax.TemplateWrapExpr:
+		inner, err := evalExpr(e.Wrapped)
+		if err != nil {
+			return nil, err
+		}
+		ref, ok := inner.(string)
+		if !ok {
+			return inner, nil
+		}
+		return "${" + ref + "}", nil
+	case *hclsyntax.TemplateExpr:
+		if len(e.Parts) == 1 {
+			return evalExpr(e.Parts[0])
+		}
+		var sb strings.Builder
+		for _, part := range e.Parts {
+			v, err := evalExpr(part)
+			if err != nil {
+				return nil, err
+			}
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported non-string template part %T", v)
+			}
+			sb.WriteString(s)
+		}
+		return sb.String(), nil
+	case *hclsyntax.TupleConsExpr:
+		out := make([]interface{}, 0, len(e.Exprs))
+		for _, elemExpr := range e.Exprs {
+			v, err := evalExpr(elemExpr)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	case *hclsyntax.ObjectConsExpr:
+		out := map[string]interface{}{}
+		for _, item := range e.Items {
+			keyVal, diags := item.KeyExpr.Value(nil)
+			if diags.HasErrors() {
+				return nil, diags
+			}
+			key, err := ctyToString(keyVal)
+			if err != nil {
+				return nil, fmt.Errorf("object key: %w", err)
+			}
+			val, err := evalExpr(item.ValueExpr)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = val
+		}
+		return out, nil
+	default:
+		val, diags := expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		return ctyToGo(val)
+	}
+}
+
+// traversalString renders a bare variable/resource reference, e.g. "aws_vpc.main" or
+// "aws_vpc.main.id", from its HCL traversal steps.
+func traversalString(traversal hcl.Traversal) (string, error) {
+	parts := make([]string, 0, len(traversal))
+	for _, step := range traversal {
+		switch s := step.(type) {
+		case hcl.TraverseRoot:
+			parts = append(parts, s.Name)
+		case hcl.TraverseAttr:
+			parts = append(parts, s.Name)
+		default:
+			return "", fmt.Errorf("unsupported reference segment %T", step)
+		}
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// ctyToGo JSON-round-trips a cty.Value into a generic Go value, the same decoding mergeConfig
+// and Convert expect for every other attribute.
+func ctyToGo(val cty.Value) (interface{}, error) {
+	jsonVal, err := ctyjson.Marshal(val, val.Type())
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(jsonVal, &decoded); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	return decoded, nil
+}
+
+// ctyToString is like ctyToGo but requires the result to be a string, for use on object
+// constructor keys (e.g. the "Name" in tags = { Name = "main" }).
+func ctyToString(val cty.Value) (string, error) {
+	decoded, err := ctyToGo(val)
+	if err != nil {
+		return "", err
+	}
+	s, ok := decoded.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a string key, got %T", decoded)
+	}
+	return s, nil
+}
+
+// mergeConfig folds a single file's raw "terraform"/"provider"/"resource" blocks into cfg.
+func mergeConfig(cfg *Config, raw map[string]interface{}) {
+	if tf, ok := raw["terraform"].(map[string]interface{}); ok {
+		if required, ok := tf["required_providers"].(map[string]interface{}); ok {
+			for name, v := range required {
+				entry, _ := v.(map[string]interface{})
+				pc := cfg.Providers[name]
+				if source, ok := entry["source"].(string); ok {
+					pc.Source = source
+				}
+				if version, ok := entry["version"].(string); ok {
+					pc.Version = version
+				}
+				cfg.Providers[name] = pc
+			}
+		}
+	}
+
+	if providers, ok := raw["provider"].(map[string]interface{}); ok {
+		for name, v := range providers {
+			meta, _ := v.(map[string]interface{})
+			pc := cfg.Providers[name]
+			pc.ProviderMeta = meta
+			cfg.Providers[name] = pc
+		}
+	}
+
+	if resources, ok := raw["resource"].(map[string]interface{}); ok {
+		for resType, byName := range resources {
+			names, _ := byName.(map[string]interface{})
+			for resName, v := range names {
+				attrs, _ := v.(map[string]interface{})
+				block := ResourceBlock{
+					Type:       resType,
+					Name:       resName,
+					Provider:   providerNameOf(resType),
+					Attributes: map[string]interface{}{},
+				}
+
+				if dependsOn, ok := attrs["depends_on"]; ok {
+					delete(attrs, "depends_on")
+					for _, dep := range toStringSlice(dependsOn) {
+						block.DependsOn = append(block.DependsOn, dep)
+					}
+				}
+				if provider, ok := attrs["provider"].(string); ok {
+					delete(attrs, "provider")
+					block.Provider = provider
+				}
+
+				block.Attributes = attrs
+				cfg.Resources = append(cfg.Resources, block)
+			}
+		}
+	}
+}
+
+func toStringSlice(v interface{}) []string {
+	switch vv := v.(type) {
+	case []interface{}:
+		out := make([]string, 0, len(vv))
+		for _, item := range vv {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	case string:
+		return []string{vv}
+	default:
+		return nil
+	}
+}
+
+// providerNameOf derives a provider name from a resource type's prefix, e.g. "aws_vpc" ->
+// "aws". It is only a fallback, used when a resource block has no explicit "provider"
+// attribute.
+func providerNameOf(resType string) string {
+	if i := strings.Index(resType, "_"); i > 0 {
+		return resType[:i]
+	}
+	return resType
+}
+
+// Convert translates a parsed Config into Kusion resources, resolving each resource's
+// ProviderConfig from cfg.Providers and rewriting any interpolation references among
+// Attributes into "$kusion_path" dependency strings, with the referenced resource's Kusion ID
+// added to DependsOn.
+func Convert(cfg *Config) ([]*v1.Resource, error) {
+	ids := make(map[string]string, len(cfg.Resources))
+	for _, res := range cfg.Resources {
+		providerCfg, ok := cfg.Providers[res.Provider]
+		if !ok {
+			return nil, fmt.Errorf("resource %s.%s: provider %q is not configured", res.Type, res.Name, res.Provider)
+		}
+		id, err := module.TerraformResourceID(providerCfg, res.Type, res.Name)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s.%s: %w", res.Type, res.Name, err)
+		}
+		ids[res.Type+"."+res.Name] = id
+	}
+
+	resources := make([]*v1.Resource, 0, len(cfg.Resources))
+	for _, res := range cfg.Resources {
+		providerCfg := cfg.Providers[res.Provider]
+
+		dependsOn := make([]string, 0, len(res.DependsOn))
+		for _, dep := range res.DependsOn {
+			id, ok := ids[dep]
+			if !ok {
+				return nil, fmt.Errorf("resource %s.%s: depends_on references unknown resource %q", res.Type, res.Name, dep)
+			}
+			dependsOn = append(dependsOn, id)
+		}
+		attrs, refs := translateInterpolations(res.Attributes, ids)
+		dependsOn = append(dependsOn, refs...)
+
+		resource, err := module.WrapTFResourceToKusionResource(providerCfg, res.Type, ids[res.Type+"."+res.Name], attrs, dedupe(dependsOn))
+		if err != nil {
+			return nil, fmt.Errorf("resource %s.%s: %w", res.Type, res.Name, err)
+		}
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
+
+// translateInterpolations rewrites each top-level string value of attrs of the form
+// "${type.name.attr}" into the Kusion dependency string "$kusion_path.<id>.attr", returning the
+// rewritten attributes alongside the Kusion IDs of every resource referenced. Values nested
+// inside lists or maps are not rewritten, and a reference to a resource not in ids is left as is.
+func translateInterpolations(attrs map[string]interface{}, ids map[string]string) (map[string]interface{}, []string) {
+	var refs []string
+	out := make(map[string]interface{}, len(attrs))
+	for k, v := range attrs {
+		translated, ref := translateValue(v, ids)
+		out[k] = translated
+		if ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return out, refs
+}
+
+func translateValue(v interface{}, ids map[string]string) (interface{}, string) {
+	switch vv := v.(type) {
+	case string:
+		match := interpRef.FindStringSubmatch(vv)
+		if match == nil {
+			return vv, ""
+		}
+		id, ok := ids[match[1]+"."+match[2]]
+		if !ok {
+			return vv, ""
+		}
+		return "$kusion_path." + id + "." + match[3], id
+	default:
+		return vv, ""
+	}
+}
+
+func dedupe(in []string) []string {
+	seen := make(map[string]struct{}, len(in))
+	out := make([]string, 0, len(in))
+	for _, s := range in {
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,150 @@
+package tfimport
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"kusionstack.io/kusion-module-framework/pkg/module"
+	v1 "kusionstack.io/kusion/pkg/apis/api.kusion.io/v1"
+)
+
+const testMainTF = `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "5.1.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = "us-west-2"
+}
+
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+
+  tags = {
+    Name = "main"
+  }
+}
+
+resource "aws_subnet" "sub" {
+  vpc_id     = "${aws_vpc.main.id}"
+  cidr_block = "10.0.1.0/24"
+  depends_on = [aws_vpc.main]
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`
+
+func parseTestDir(t *testing.T, content string) *Config {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(content), 0o600); err != nil {
+		t.Fatalf("write main.tf: %v", err)
+	}
+
+	cfg, err := ParseDir(dir)
+	if err != nil {
+		t.Fatalf("ParseDir: %v", err)
+	}
+	return cfg
+}
+
+// TestParseDirRequiredProvidersAndNestedBlocks exercises the two shapes that previously made
+// ParseDir fail on ordinary Terraform: required_providers as a nested block (not an attribute)
+// inside "terraform { }", and a resource with a nested block ("lifecycle") alongside its other
+// attributes.
+func TestParseDirRequiredProvidersAndNestedBlocks(t *testing.T) {
+	cfg := parseTestDir(t, testMainTF)
+
+	aws, ok := cfg.Providers["aws"]
+	if !ok {
+		t.Fatalf("provider %q not parsed from required_providers block", "aws")
+	}
+	if aws.Source != "hashicorp/aws" {
+		t.Errorf("Source = %q, want %q", aws.Source, "hashicorp/aws")
+	}
+	if aws.Version != "5.1.0" {
+		t.Errorf("Version = %q, want %q", aws.Version, "5.1.0")
+	}
+
+	var subnet *ResourceBlock
+	for i := range cfg.Resources {
+		if cfg.Resources[i].Type == "aws_subnet" {
+			subnet = &cfg.Resources[i]
+		}
+	}
+	if subnet == nil {
+		t.Fatalf("aws_subnet.sub not parsed (nested lifecycle block likely caused an error)")
+	}
+	if len(subnet.DependsOn) != 1 || subnet.DependsOn[0] != "aws_vpc.main" {
+		t.Errorf("subnet.DependsOn = %v, want [aws_vpc.main]", subnet.DependsOn)
+	}
+}
+
+// TestConvertResolvesDependsOnAndInterpolations checks that both an explicit depends_on address
+// and an attribute interpolation reference are rewritten into the referenced resource's Kusion
+// ID, rather than being left as a raw Terraform address that cannot match anything.
+func TestConvertResolvesDependsOnAndInterpolations(t *testing.T) {
+	cfg := parseTestDir(t, testMainTF)
+
+	resources, err := Convert(cfg)
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+
+	var vpcID string
+	var subnet *v1.Resource
+	for _, r := range resources {
+		if _, ok := r.Attributes["vpc_id"]; ok {
+			subnet = r
+			continue
+		}
+		vpcID = r.ID
+	}
+	if subnet == nil {
+		t.Fatalf("aws_subnet resource not found in converted output")
+	}
+	if vpcID == "" {
+		t.Fatalf("aws_vpc resource not found in converted output")
+	}
+
+	if len(subnet.DependsOn) != 1 || subnet.DependsOn[0] != vpcID {
+		t.Errorf("subnet.DependsOn = %v, want [%s] (resolved from depends_on = [aws_vpc.main])", subnet.DependsOn, vpcID)
+	}
+
+	want := "$kusion_path." + vpcID + ".id"
+	if got := subnet.Attributes["vpc_id"]; got != want {
+		t.Errorf("subnet.Attributes[vpc_id] = %v, want %q (interpolation ref rewritten)", got, want)
+	}
+}
+
+// TestConvertErrorsOnUnknownDependsOn ensures a depends_on address that doesn't match any
+// parsed resource is reported as an error instead of silently passed through.
+func TestConvertErrorsOnUnknownDependsOn(t *testing.T) {
+	cfg := &Config{
+		Providers: map[string]module.ProviderConfig{
+			"aws": {Source: "hashicorp/aws", Version: "5.1.0"},
+		},
+		Resources: []ResourceBlock{
+			{
+				Type:       "aws_subnet",
+				Name:       "sub",
+				Provider:   "aws",
+				Attributes: map[string]interface{}{"cidr_block": "10.0.1.0/24"},
+				DependsOn:  []string{"aws_vpc.missing"},
+			},
+		},
+	}
+
+	if _, err := Convert(cfg); err == nil {
+		t.Fatal("Convert: expected error for depends_on referencing an unknown resource, got nil")
+	}
+}
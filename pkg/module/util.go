@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -15,8 +16,58 @@ var ErrEmptyTFProviderVersion = errors.New("empty terraform provider version")
 
 var defaultTFHost = "registry.terraform.io"
 
-func WrapK8sResourceToKusionResource(id string, resource any) (*v1.Resource, error) {
-	gvk := resource.(runtime.Object).GetObjectKind().GroupVersionKind().String()
+// wrapK8sResourceOptions holds the options configured via WrapK8sResourceOption.
+type wrapK8sResourceOptions struct {
+	idStrategy IDStrategy
+}
+
+// WrapK8sResourceOption customizes WrapK8sResourceToKusionResource.
+type WrapK8sResourceOption func(*wrapK8sResourceOptions)
+
+// WithIDStrategy makes WrapK8sResourceToKusionResource compute the resource ID using strategy
+// instead of the default apiVersion:Kind:ns:name format. It only takes effect when id is passed
+// as the empty string.
+func WithIDStrategy(strategy IDStrategy) WrapK8sResourceOption {
+	return func(o *wrapK8sResourceOptions) {
+		o.idStrategy = strategy
+	}
+}
+
+// WrapK8sResourceToKusionResource wraps the Kubernetes resource into the format of the Kusion
+// resource. If id is empty, it is computed from resource's own type and object metadata using
+// the configured IDStrategy (module.defaultIDStrategy unless overridden via WithIDStrategy).
+func WrapK8sResourceToKusionResource(id string, resource any, opts ...WrapK8sResourceOption) (*v1.Resource, error) {
+	options := &wrapK8sResourceOptions{idStrategy: defaultIDStrategy{}}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	obj := resource.(runtime.Object)
+	gvk := obj.GetObjectKind().GroupVersionKind().String()
+
+	if id == "" {
+		accessor, err := meta.Accessor(resource)
+		if err != nil {
+			return nil, fmt.Errorf("resolve object metadata: %w", err)
+		}
+
+		if truncator, ok := options.idStrategy.(nameTruncator); ok {
+			if truncated, changed := truncator.TruncateName(accessor.GetName()); changed {
+				accessor.SetName(truncated)
+			}
+		}
+
+		typeMeta := metav1.TypeMeta{
+			APIVersion: obj.GetObjectKind().GroupVersionKind().GroupVersion().String(),
+			Kind:       obj.GetObjectKind().GroupVersionKind().Kind,
+		}
+		objectMeta := metav1.ObjectMeta{Namespace: accessor.GetNamespace(), Name: accessor.GetName()}
+
+		id, err = options.idStrategy.ID(typeMeta, objectMeta)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// fixme: this function converts int to int64 by default
 	unstructured, err := runtime.DefaultUnstructuredConverter.ToUnstructured(resource)
@@ -35,6 +86,9 @@ func WrapK8sResourceToKusionResource(id string, resource any) (*v1.Resource, err
 }
 
 // KubernetesResourceID returns the ID of a Kubernetes resource based on its type and metadata. Resource ID should be unique in one Spec.
+//
+// To additionally enforce that the ID is unique within a Spec at generation time, use
+// ResourceIDRegistry.RegisterKubernetesResourceID instead.
 func KubernetesResourceID(typeMeta metav1.TypeMeta, objectMeta metav1.ObjectMeta) string {
 	// resource id example: apps/v1:Deployment:nginx:nginx-deployment
 	id := typeMeta.APIVersion + ":" + typeMeta.Kind + ":"
@@ -95,6 +149,9 @@ type ProviderConfig struct {
 }
 
 // TerraformResourceID returns the Kusion resource ID of the Terraform resource. Resource ID should be unique in one Spec.
+//
+// To additionally enforce that the ID is unique within a Spec at generation time, use
+// ResourceIDRegistry.RegisterTerraformResourceID instead.
 func TerraformResourceID(providerCfg ProviderConfig, resType, resName string) (string, error) {
 	if providerCfg.Version == "" {
 		return "", ErrEmptyTFProviderVersion